@@ -2,6 +2,7 @@ package monitoring
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"monitoring-service/pocket"
 	"sort"
@@ -10,6 +11,8 @@ import (
 	"gopkg.in/errgo.v2/fmt/errors"
 
 	"github.com/go-kit/kit/endpoint"
+
+	pocketerrors "monitoring-service/provider/pocket/errors"
 )
 
 type Endpoints struct {
@@ -20,6 +23,79 @@ type Endpoints struct {
 	AccountTransactions endpoint.Endpoint
 	BlockTimes          endpoint.Endpoint
 	MonthlyRewards      endpoint.Endpoint
+	PoolStatus          endpoint.Endpoint
+	CacheStats          endpoint.Endpoint
+	LiveSubscribers     endpoint.Endpoint
+}
+
+// endpointState mirrors provider/pocket.EndpointState so this package doesn't
+// have to depend on the provider package for a single response shape.
+type endpointState struct {
+	URL                 string        `json:"url"`
+	Healthy             bool          `json:"healthy"`
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+	LastLatency         time.Duration `json:"last_latency_ns"`
+}
+
+// poolStatusProvider is the narrow capability PoolStatusEndpoint needs from
+// the service, so it doesn't have to depend on the full Service interface
+// shape to be added here.
+type poolStatusProvider interface {
+	PoolStatus() []endpointState
+}
+
+// PoolStatusEndpoint reports the health of every node endpoint the service
+// is load-balancing across, so operators can see when a community RPC
+// gateway has gone unhealthy without digging through logs.
+func PoolStatusEndpoint(svc poolStatusProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		return svc.PoolStatus(), nil
+	}
+}
+
+// cacheStatsResponse mirrors provider/pocket/cache.Stats so this package
+// doesn't have to depend on the cache package for two counters.
+type cacheStatsResponse struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// cacheStatsProvider is the narrow capability CacheStatsEndpoint needs from
+// the service.
+type cacheStatsProvider interface {
+	CacheHits() int64
+	CacheMisses() int64
+}
+
+// CacheStatsEndpoint reports the response cache's running hit/miss counts,
+// so operators trading RAM/disk for RPC pressure can see whether it's
+// working.
+func CacheStatsEndpoint(svc cacheStatsProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		return cacheStatsResponse{
+			Hits:   svc.CacheHits(),
+			Misses: svc.CacheMisses(),
+		}, nil
+	}
+}
+
+// liveSubscribersProvider is the narrow capability LiveSubscribersEndpoint
+// needs from the service.
+type liveSubscribersProvider interface {
+	LiveSubscribers() int64
+}
+
+type liveSubscribersResponse struct {
+	Count int64 `json:"count"`
+}
+
+// LiveSubscribersEndpoint reports how many websocket clients are currently
+// subscribed to live tip/node events, so operators can see subscriber load
+// the same way they can already see pool health and cache hit rate.
+func LiveSubscribersEndpoint(svc liveSubscribersProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		return liveSubscribersResponse{Count: svc.LiveSubscribers()}, nil
+	}
 }
 
 type heightResponse struct {
@@ -30,7 +106,7 @@ func HeightEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		height, err := svc.Height()
 		if err != nil {
-			return nil, fmt.Errorf("HeightEndpoint: %s", err)
+			return nil, fmt.Errorf("HeightEndpoint: %w", err)
 		}
 
 		response = heightResponse{Height: height}
@@ -65,10 +141,18 @@ type relaysByChain struct {
 	NumRelays uint   `json:"num_relays"`
 }
 
+// monthlyRewardsEndpointResponse wraps the per-month results with a Stale
+// flag so clients can tell a degraded (node-behind-tip) response from a
+// fully caught-up one.
+type monthlyRewardsEndpointResponse struct {
+	Months []monthlyRewardsResponse `json:"months"`
+	Stale  bool                     `json:"stale"`
+}
+
 func MonthlyRewardsEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		fail := func(err error) (interface{}, error) {
-			return nil, fmt.Errorf("MonthlyRewardsEndpoint: %s", err)
+			return nil, fmt.Errorf("MonthlyRewardsEndpoint: %w", err)
 		}
 
 		req, ok := request.(monthlyRewardsRequest)
@@ -78,7 +162,10 @@ func MonthlyRewardsEndpoint(svc Service) endpoint.Endpoint {
 		}
 
 		months, err := svc.RewardsByMonth(req.Address)
-		if err != nil {
+
+		var outOfSync *pocketerrors.OutOfSyncError
+		stale := stderrors.As(err, &outOfSync)
+		if err != nil && !stale {
 			return fail(err)
 		}
 
@@ -156,7 +243,7 @@ func MonthlyRewardsEndpoint(svc Service) endpoint.Endpoint {
 			return resp[i].Year > resp[j].Year
 		})
 
-		return resp, nil
+		return monthlyRewardsEndpointResponse{Months: resp, Stale: stale}, nil
 	}
 }
 
@@ -166,10 +253,27 @@ type blockTimesRequest struct {
 
 type blockTimesResponse map[uint]time.Time
 
-func BlockTimesEndpoint(svc Service) endpoint.Endpoint {
+// blockTimesEndpointResponse wraps the resolved block times with an Error
+// string so a partial batch failure (one bad height among many) doesn't
+// cost the caller every height that did resolve, the same way
+// monthlyRewardsEndpointResponse carries Stale instead of failing outright.
+type blockTimesEndpointResponse struct {
+	Blocks blockTimesResponse `json:"blocks"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// blockTimesProvider is the narrow capability BlockTimesEndpoint needs from
+// the service: a batched lookup instead of one round-trip per height, so a
+// month of rewards doesn't serialize hundreds of cold-cache block-time
+// fetches behind each other.
+type blockTimesProvider interface {
+	BatchBlockTimes(heights []uint) (map[uint]time.Time, error)
+}
+
+func BlockTimesEndpoint(svc blockTimesProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		fail := func(err error) (interface{}, error) {
-			return nil, fmt.Errorf("BlockTimesEndpoint: %s", err)
+			return nil, fmt.Errorf("BlockTimesEndpoint: %w", err)
 		}
 
 		req, ok := request.(blockTimesRequest)
@@ -178,12 +282,14 @@ func BlockTimesEndpoint(svc Service) endpoint.Endpoint {
 			return fail(err)
 		}
 
-		blocks, err := svc.BlockTimes(req.Heights)
+		blocks, err := svc.BatchBlockTimes(req.Heights)
+
+		resp := blockTimesEndpointResponse{Blocks: blocks}
 		if err != nil {
-			return fail(err)
+			resp.Error = err.Error()
 		}
 
-		return blocks, nil
+		return resp, nil
 	}
 }
 
@@ -198,7 +304,7 @@ type paramsResponse struct {
 func ParamsEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		fail := func(err error) (interface{}, error) {
-			return nil, fmt.Errorf("ParamsEndpoint: %s", err)
+			return nil, fmt.Errorf("ParamsEndpoint: %w", err)
 		}
 
 		req, ok := request.(paramsRequest)
@@ -238,7 +344,7 @@ type transactionResponse struct {
 func TransactionEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		fail := func(err error) (interface{}, error) {
-			return nil, fmt.Errorf("TransactionEndpoint: %s", err)
+			return nil, fmt.Errorf("TransactionEndpoint: %w", err)
 		}
 
 		req, ok := request.(transactionRequest)
@@ -276,7 +382,7 @@ type accountTransactionsResponse []transactionResponse
 func AccountTransactionsEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		fail := func(err error) (interface{}, error) {
-			return nil, fmt.Errorf("AccountTransactionsEndpoint: %s", err)
+			return nil, fmt.Errorf("AccountTransactionsEndpoint: %w", err)
 		}
 
 		req, ok := request.(accountTransactionsRequest)
@@ -335,7 +441,7 @@ func (req relayRequest) validate() error {
 func SimulateRelayEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		fail := func(err error) (interface{}, error) {
-			return nil, fmt.Errorf("SimulateRelayEndpoint: %s", err)
+			return nil, fmt.Errorf("SimulateRelayEndpoint: %w", err)
 		}
 
 		req, ok := request.(relayRequest)
@@ -382,7 +488,7 @@ type chainResponse struct {
 func NodeEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		fail := func(err error) (interface{}, error) {
-			return nil, fmt.Errorf("NodeEndpoint: %s", err)
+			return nil, fmt.Errorf("NodeEndpoint: %w", err)
 		}
 
 		req, ok := request.(nodeRequest)
@@ -0,0 +1,36 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+
+	pocketerrors "monitoring-service/provider/pocket/errors"
+)
+
+// EncodeError maps a pocketerrors.NodeError to the HTTP status it deserves
+// instead of the go-kit default of a blanket 500, so e.g. a missing address
+// surfaces as a 404 rather than looking like a server bug.
+func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	status := http.StatusInternalServerError
+
+	var notFound *pocketerrors.NotFoundError
+	var rateLimited *pocketerrors.RateLimitedError
+	var outOfSync *pocketerrors.OutOfSyncError
+
+	switch {
+	case stderrors.As(err, &notFound):
+		status = http.StatusNotFound
+	case stderrors.As(err, &rateLimited):
+		status = http.StatusTooManyRequests
+	case stderrors.As(err, &outOfSync):
+		status = http.StatusBadGateway
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+const contentTypeJSON = "application/json; charset=UTF-8"
@@ -0,0 +1,253 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// socketReadDeadline/socketWriteDeadline bound how long a subscriber
+// connection may go without a ping before it's considered dead.
+const (
+	socketReadDeadline  = 60 * time.Second
+	socketWriteDeadline = 10 * time.Second
+	socketPingInterval  = 30 * time.Second
+)
+
+// liveFeed is the subset of the pocket provider a subscriber channel needs.
+// It's kept narrow and package-local so the socket layer doesn't have to
+// depend on the full Service interface.
+type liveFeed interface {
+	WatchTip(ctx context.Context, interval time.Duration) (<-chan pocketTipEvent, error)
+	WatchNode(ctx context.Context, address string, interval time.Duration) (<-chan pocketNodeEvent, error)
+}
+
+// pocketTipEvent and pocketNodeEvent mirror the event structs published by
+// provider/pocket.TipEvent and provider/pocket.NodeEvent. They're redeclared
+// here to keep this package decoupled from the provider package; the Service
+// implementation is responsible for bridging the two.
+type pocketTipEvent struct {
+	Height uint      `json:"height"`
+	Time   time.Time `json:"time"`
+	Stale  bool      `json:"stale"`
+}
+
+type pocketNodeEvent struct {
+	Address       string `json:"address"`
+	IsJailed      bool   `json:"is_jailed"`
+	StakedBalance uint   `json:"staked_balance"`
+	Height        uint   `json:"height"`
+	Stale         bool   `json:"stale"`
+}
+
+// socketEvent is the envelope written to every subscriber.
+type socketEvent struct {
+	Channel string      `json:"channel"`
+	Address string      `json:"address,omitempty"`
+	Data    interface{} `json:"data"`
+}
+
+// SocketHub fans out live node/tip events to subscribed websocket
+// connections, similar in spirit to blockbook's SocketIoServer. Relay-proof
+// and rolling-reward-delta channels aren't implemented: neither the provider
+// nor the Service interface exposes an event source for them yet (no relay
+// proof stream, no incremental reward recompute), so adding the channels
+// here would just be unbacked scaffolding. LiveSubscribers is exposed via
+// LiveSubscribersEndpoint for whichever channels do exist.
+type SocketHub struct {
+	upgrader websocket.Upgrader
+
+	mu          sync.Mutex
+	subscribers map[string]map[*socketSubscriber]struct{}
+	liveCount   int64
+}
+
+type socketSubscriber struct {
+	conn    *websocket.Conn
+	send    chan socketEvent
+	channel string
+}
+
+// NewSocketHub builds a hub ready to accept subscriptions.
+func NewSocketHub() *SocketHub {
+	return &SocketHub{
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     func(r *http.Request) bool { return true },
+		},
+		subscribers: make(map[string]map[*socketSubscriber]struct{}),
+	}
+}
+
+// LiveSubscribers returns the current count of connected websocket clients,
+// surfaced through the monitoring endpoints as a gauge.
+func (h *SocketHub) LiveSubscribers() int64 {
+	return atomic.LoadInt64(&h.liveCount)
+}
+
+func (h *SocketHub) add(sub *socketSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[sub.channel] == nil {
+		h.subscribers[sub.channel] = make(map[*socketSubscriber]struct{})
+	}
+	h.subscribers[sub.channel][sub] = struct{}{}
+	atomic.AddInt64(&h.liveCount, 1)
+}
+
+func (h *SocketHub) remove(sub *socketSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers[sub.channel], sub)
+	if len(h.subscribers[sub.channel]) == 0 {
+		delete(h.subscribers, sub.channel)
+	}
+	atomic.AddInt64(&h.liveCount, -1)
+	close(sub.send)
+}
+
+func (h *SocketHub) broadcast(channel string, evt socketEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[channel] {
+		select {
+		case sub.send <- evt:
+		default:
+			// subscriber is too slow to keep up; drop the event rather than
+			// block the hub.
+		}
+	}
+}
+
+// SubscribeHandler upgrades the connection to a websocket and streams
+// per-address node/tip events for as long as the client stays connected.
+// Channels: "tip" (no address required) and "node" (requires an "address"
+// query param). These are the only two channels this hub supports.
+func (h *SocketHub) SubscribeHandler(feed liveFeed) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		channel := r.URL.Query().Get("channel")
+		address := r.URL.Query().Get("address")
+		if channel == "" {
+			http.Error(w, "missing required query param 'channel'", http.StatusBadRequest)
+			return
+		}
+		if channel == "node" && address == "" {
+			http.Error(w, "channel 'node' requires an 'address' query param", http.StatusBadRequest)
+			return
+		}
+		if channel != "tip" && channel != "node" {
+			http.Error(w, fmt.Sprintf("unknown channel %q", channel), http.StatusBadRequest)
+			return
+		}
+
+		conn, err := h.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		key := channel
+		if address != "" {
+			key = fmt.Sprintf("%s:%s", channel, address)
+		}
+
+		sub := &socketSubscriber{
+			conn:    conn,
+			send:    make(chan socketEvent, 16),
+			channel: key,
+		}
+		h.add(sub)
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		switch channel {
+		case "tip":
+			go h.pumpTip(ctx, feed, sub)
+		case "node":
+			go h.pumpNode(ctx, feed, address, sub)
+		}
+
+		h.writeLoop(sub)
+		cancel()
+		h.remove(sub)
+	}
+}
+
+func (h *SocketHub) pumpTip(ctx context.Context, feed liveFeed, sub *socketSubscriber) {
+	events, err := feed.WatchTip(ctx, 0)
+	if err != nil {
+		return
+	}
+
+	for evt := range events {
+		h.broadcast(sub.channel, socketEvent{Channel: "tip", Data: evt})
+	}
+}
+
+func (h *SocketHub) pumpNode(ctx context.Context, feed liveFeed, address string, sub *socketSubscriber) {
+	events, err := feed.WatchNode(ctx, address, 0)
+	if err != nil {
+		return
+	}
+
+	for evt := range events {
+		h.broadcast(sub.channel, socketEvent{Channel: "node", Address: address, Data: evt})
+	}
+}
+
+// writeLoop drains sub.send to the websocket connection, enforcing a write
+// deadline per message and a ping/pong keepalive to detect dead peers.
+func (h *SocketHub) writeLoop(sub *socketSubscriber) {
+	ticker := time.NewTicker(socketPingInterval)
+	defer ticker.Stop()
+	defer sub.conn.Close()
+
+	sub.conn.SetReadDeadline(time.Now().Add(socketReadDeadline))
+	sub.conn.SetPongHandler(func(string) error {
+		sub.conn.SetReadDeadline(time.Now().Add(socketReadDeadline))
+		return nil
+	})
+
+	go func() {
+		// discard anything the client sends; we only care about pongs
+		// resetting the read deadline.
+		for {
+			if _, _, err := sub.conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case evt, ok := <-sub.send:
+			if !ok {
+				return
+			}
+
+			sub.conn.SetWriteDeadline(time.Now().Add(socketWriteDeadline))
+			payload, err := json.Marshal(evt)
+			if err != nil {
+				continue
+			}
+			if err := sub.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			sub.conn.SetWriteDeadline(time.Now().Add(socketWriteDeadline))
+			if err := sub.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package pocket
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// batchBlockTimesWorkers bounds how many block-time lookups BatchBlockTimes
+// runs concurrently against the node.
+const batchBlockTimesWorkers = 8
+
+// BatchBlockTimes resolves block times for heights, fanning uncached
+// lookups out over a bounded worker pool and coalescing concurrent duplicate
+// lookups for the same height via singleflight (common when many months of
+// rewards are computed at once). Newly-fetched times are written back to the
+// cache in a single batched call instead of one write per height.
+func (p pocketProvider) BatchBlockTimes(heights []uint) (map[uint]time.Time, error) {
+	results := make(map[uint]time.Time, len(heights))
+	toFetch := make([]uint, 0, len(heights))
+
+	for _, height := range heights {
+		if cached, exists, err := p.cache.GetBlockTime(height); err == nil && exists {
+			results[height] = cached
+			continue
+		}
+		toFetch = append(toFetch, height)
+	}
+
+	fetched, fetchErr := p.fetchBlockTimesConcurrently(toFetch)
+	for height, t := range fetched {
+		results[height] = t
+	}
+
+	if len(fetched) > 0 {
+		if err := p.cache.SetBlockTimes(fetched); err != nil && fetchErr == nil {
+			fetchErr = err
+		}
+	}
+
+	// A height that failed to fetch shouldn't cost the caller the heights
+	// that succeeded, including ones that were already cache hits; return
+	// what we have alongside the error.
+	if fetchErr != nil {
+		return results, fmt.Errorf("pocketProvider.BatchBlockTimes: %w", fetchErr)
+	}
+
+	return results, nil
+}
+
+func (p pocketProvider) fetchBlockTimesConcurrently(heights []uint) (map[uint]time.Time, error) {
+	if len(heights) == 0 {
+		return nil, nil
+	}
+
+	workers := batchBlockTimesWorkers
+	if workers > len(heights) {
+		workers = len(heights)
+	}
+
+	heightCh := make(chan uint)
+	results := make(map[uint]time.Time, len(heights))
+
+	var mu sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for height := range heightCh {
+				v, err, _ := p.blockTimeInFlight.Do(strconv.FormatUint(uint64(height), 10), func() (interface{}, error) {
+					return p.fetchBlockTime(height)
+				})
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					results[height] = v.(time.Time)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, height := range heights {
+		heightCh <- height
+	}
+	close(heightCh)
+	wg.Wait()
+
+	// Return whatever heights succeeded alongside firstErr rather than
+	// discarding them: one bad height (a 404, a timeout) shouldn't cost the
+	// caller every other height in the batch.
+	return results, firstErr
+}
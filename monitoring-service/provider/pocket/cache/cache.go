@@ -0,0 +1,21 @@
+// Package cache generalizes the provider's original block-time-only cache
+// into a pluggable, per-type TTL cache backing block times, transactions,
+// nodes, balances, and account-transaction pages.
+package cache
+
+import "time"
+
+// Backend is the pluggable storage behind Store: a TTL-aware byte-value
+// cache keyed by string. A ttl of 0 means "cache forever".
+type Backend interface {
+	Get(key string) (value []byte, exists bool, err error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Close() error
+}
+
+// BatchSetter is an optional Backend capability for a native multi-key
+// write. Backends that don't implement it get one Set call per key from
+// Store.SetBlockTimes.
+type BatchSetter interface {
+	SetMany(values map[string][]byte, ttl time.Duration) error
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var diskCacheBucket = []byte("pocket_cache")
+
+// BoltBackend is an on-disk Backend backed by BoltDB, for operators who want
+// the cache to survive restarts without standing up Redis.
+type BoltBackend struct {
+	db *bolt.DB
+}
+
+// NewBoltBackend opens (creating if necessary) a BoltDB file at path.
+func NewBoltBackend(path string) (*BoltBackend, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("cache.NewBoltBackend: %s", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(diskCacheBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cache.NewBoltBackend: %s", err)
+	}
+
+	return &BoltBackend{db: db}, nil
+}
+
+type diskEntry struct {
+	Value     []byte    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (b *BoltBackend) Get(key string) ([]byte, bool, error) {
+	var raw []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(diskCacheBucket).Get([]byte(key))
+		if v != nil {
+			raw = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("BoltBackend.Get: %s", err)
+	}
+	if raw == nil {
+		return nil, false, nil
+	}
+
+	var e diskEntry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return nil, false, fmt.Errorf("BoltBackend.Get: %s", err)
+	}
+	if !e.ExpiresAt.IsZero() && time.Now().After(e.ExpiresAt) {
+		return nil, false, nil
+	}
+
+	return e.Value, true, nil
+}
+
+func (b *BoltBackend) Set(key string, value []byte, ttl time.Duration) error {
+	e := diskEntry{Value: value}
+	if ttl > 0 {
+		e.ExpiresAt = time.Now().Add(ttl)
+	}
+
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("BoltBackend.Set: %s", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(diskCacheBucket).Put([]byte(key), raw)
+	})
+}
+
+func (b *BoltBackend) Close() error {
+	return b.db.Close()
+}
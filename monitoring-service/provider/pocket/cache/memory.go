@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time // zero means never
+}
+
+// MemoryBackend is an in-process, map-backed Backend. It's the default and
+// matches the provider's original in-memory block-time cache behavior.
+type MemoryBackend struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+}
+
+// NewMemoryBackend builds an empty in-memory backend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{entries: make(map[string]memoryEntry)}
+}
+
+func (m *MemoryBackend) Get(key string) ([]byte, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !e.expiresAt.IsZero() && time.Now().After(e.expiresAt) {
+		return nil, false, nil
+	}
+
+	return e.value, true, nil
+}
+
+func (m *MemoryBackend) Set(key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := memoryEntry{value: value}
+	if ttl > 0 {
+		e.expiresAt = time.Now().Add(ttl)
+	}
+	m.entries[key] = e
+
+	return nil
+}
+
+func (m *MemoryBackend) Close() error {
+	return nil
+}
+
+// SetMany implements BatchSetter.
+func (m *MemoryBackend) SetMany(values map[string][]byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	for key, value := range values {
+		m.entries[key] = memoryEntry{value: value, expiresAt: expiresAt}
+	}
+
+	return nil
+}
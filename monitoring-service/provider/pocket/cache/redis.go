@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisBackend is a Backend over a shared Redis instance, for deployments
+// that already run Redis and want the cache to survive a restart or be
+// shared across multiple calculator instances.
+type RedisBackend struct {
+	client *redis.Client
+}
+
+// NewRedisBackend builds a backend against the Redis instance at addr (e.g.
+// "localhost:6379").
+func NewRedisBackend(addr string) *RedisBackend {
+	return &RedisBackend{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+func (r *RedisBackend) Get(key string) ([]byte, bool, error) {
+	value, err := r.client.Get(context.Background(), key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("RedisBackend.Get: %s", err)
+	}
+
+	return value, true, nil
+}
+
+func (r *RedisBackend) Set(key string, value []byte, ttl time.Duration) error {
+	if err := r.client.Set(context.Background(), key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("RedisBackend.Set: %s", err)
+	}
+
+	return nil
+}
+
+func (r *RedisBackend) Close() error {
+	return r.client.Close()
+}
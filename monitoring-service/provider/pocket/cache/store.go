@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"monitoring-service/pocket"
+)
+
+// TTLConfig sets how long each response type stays cached before a fresh
+// node round-trip is required. A zero TTL means "cache forever", which is
+// appropriate for data that's immutable once observed.
+type TTLConfig struct {
+	BlockTime           time.Duration
+	Transaction         time.Duration
+	Node                time.Duration
+	Balance             time.Duration
+	AccountTransactions time.Duration
+}
+
+// DefaultTTLConfig matches the cache policy operators running against their
+// own archival node want by default: block times and confirmed transactions
+// never change once observed, while node/balance data is refreshed every few
+// seconds.
+func DefaultTTLConfig() TTLConfig {
+	return TTLConfig{
+		BlockTime:           0,
+		Transaction:         0,
+		Node:                10 * time.Second,
+		Balance:             10 * time.Second,
+		AccountTransactions: 10 * time.Second,
+	}
+}
+
+// Config selects the cache backend and TTL policy for a Store.
+type Config struct {
+	Backend Backend
+	TTLs    TTLConfig
+}
+
+// Stats tracks cache hit/miss counts, surfaced through the monitoring
+// endpoints so operators can tell whether trading RAM/disk for RPC pressure
+// is actually working.
+type Stats struct {
+	hits   int64
+	misses int64
+}
+
+// Hits returns the running count of cache hits.
+func (s *Stats) Hits() int64 { return atomic.LoadInt64(&s.hits) }
+
+// Misses returns the running count of cache misses.
+func (s *Stats) Misses() int64 { return atomic.LoadInt64(&s.misses) }
+
+func (s *Stats) recordHit()  { atomic.AddInt64(&s.hits, 1) }
+func (s *Stats) recordMiss() { atomic.AddInt64(&s.misses, 1) }
+
+// Store is a typed cache over a pluggable Backend, with a TTL per response
+// type and hit/miss accounting.
+type Store struct {
+	backend Backend
+	ttls    TTLConfig
+	stats   *Stats
+}
+
+// NewStore builds a Store. A nil Backend defaults to an in-memory one, so
+// callers that don't care about persistence don't have to think about it.
+func NewStore(cfg Config) *Store {
+	backend := cfg.Backend
+	if backend == nil {
+		backend = NewMemoryBackend()
+	}
+
+	return &Store{backend: backend, ttls: cfg.TTLs, stats: &Stats{}}
+}
+
+// Stats returns the running hit/miss counters for this store.
+func (s *Store) Stats() *Stats {
+	return s.stats
+}
+
+func (s *Store) get(key string, dest interface{}) (bool, error) {
+	raw, exists, err := s.backend.Get(key)
+	if err != nil {
+		return false, fmt.Errorf("cache.Store.get: %s", err)
+	}
+	if !exists {
+		s.stats.recordMiss()
+		return false, nil
+	}
+
+	if err := json.Unmarshal(raw, dest); err != nil {
+		return false, fmt.Errorf("cache.Store.get: %s", err)
+	}
+
+	s.stats.recordHit()
+	return true, nil
+}
+
+func (s *Store) set(key string, ttl time.Duration, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("cache.Store.set: %s", err)
+	}
+
+	return s.backend.Set(key, raw, ttl)
+}
+
+func blockTimeKey(height uint) string { return fmt.Sprintf("blocktime:%d", height) }
+
+// GetBlockTime looks up a cached block time.
+func (s *Store) GetBlockTime(height uint) (t time.Time, exists bool, err error) {
+	exists, err = s.get(blockTimeKey(height), &t)
+	return t, exists, err
+}
+
+// SetBlockTime caches a single block time.
+func (s *Store) SetBlockTime(height uint, t time.Time) error {
+	return s.set(blockTimeKey(height), s.ttls.BlockTime, t)
+}
+
+// SetBlockTimes writes many block times in one backend round-trip where the
+// backend supports it, falling back to one write per entry otherwise.
+func (s *Store) SetBlockTimes(times map[uint]time.Time) error {
+	batcher, ok := s.backend.(BatchSetter)
+	if !ok {
+		for height, t := range times {
+			if err := s.SetBlockTime(height, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	encoded := make(map[string][]byte, len(times))
+	for height, t := range times {
+		raw, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Errorf("cache.Store.SetBlockTimes: %s", err)
+		}
+		encoded[blockTimeKey(height)] = raw
+	}
+
+	if err := batcher.SetMany(encoded, s.ttls.BlockTime); err != nil {
+		return fmt.Errorf("cache.Store.SetBlockTimes: %s", err)
+	}
+
+	return nil
+}
+
+func txKey(hash string) string { return fmt.Sprintf("tx:%s", hash) }
+
+// GetTransaction looks up a cached transaction by hash.
+func (s *Store) GetTransaction(hash string) (txn pocket.Transaction, exists bool, err error) {
+	exists, err = s.get(txKey(hash), &txn)
+	return txn, exists, err
+}
+
+// SetTransaction caches a transaction, kept for TTLConfig.Transaction.
+// Confirmed transactions are immutable, so that TTL is typically 0 (forever).
+func (s *Store) SetTransaction(hash string, txn pocket.Transaction) error {
+	return s.set(txKey(hash), s.ttls.Transaction, txn)
+}
+
+func nodeKey(address string) string { return fmt.Sprintf("node:%s", address) }
+
+// GetNode looks up a cached node by address.
+func (s *Store) GetNode(address string) (node pocket.Node, exists bool, err error) {
+	exists, err = s.get(nodeKey(address), &node)
+	return node, exists, err
+}
+
+// SetNode caches a node, refreshed on TTLConfig.Node.
+func (s *Store) SetNode(address string, node pocket.Node) error {
+	return s.set(nodeKey(address), s.ttls.Node, node)
+}
+
+func balanceKey(address string) string { return fmt.Sprintf("balance:%s", address) }
+
+// GetBalance looks up a cached balance by address.
+func (s *Store) GetBalance(address string) (balance uint, exists bool, err error) {
+	exists, err = s.get(balanceKey(address), &balance)
+	return balance, exists, err
+}
+
+// SetBalance caches a balance, refreshed on TTLConfig.Balance.
+func (s *Store) SetBalance(address string, balance uint) error {
+	return s.set(balanceKey(address), s.ttls.Balance, balance)
+}
+
+func accountTxsKey(address string, page, perPage uint, sort string) string {
+	return fmt.Sprintf("accounttxs:%s:%d:%d:%s", address, page, perPage, sort)
+}
+
+// GetAccountTransactions looks up a cached page of an address's
+// transactions.
+func (s *Store) GetAccountTransactions(address string, page, perPage uint, sort string) (txns []pocket.Transaction, exists bool, err error) {
+	exists, err = s.get(accountTxsKey(address, page, perPage, sort), &txns)
+	return txns, exists, err
+}
+
+// SetAccountTransactions caches a page of an address's transactions,
+// refreshed on TTLConfig.AccountTransactions.
+func (s *Store) SetAccountTransactions(address string, page, perPage uint, sort string, txns []pocket.Transaction) error {
+	return s.set(accountTxsKey(address, page, perPage, sort), s.ttls.AccountTransactions, txns)
+}
@@ -0,0 +1,120 @@
+package pocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"monitoring-service/provider/pocket/cache"
+	"monitoring-service/provider/pocket/testvectors"
+)
+
+// TestConformance replays every fixture under testvectors/testdata against a
+// real pocketProvider backed by an httptest.Server that serves the fixture's
+// recorded response. Set SKIP_CONFORMANCE=1 to skip this suite, e.g. when
+// working offline without the vector corpus checked out.
+func TestConformance(t *testing.T) {
+	if os.Getenv("SKIP_CONFORMANCE") != "" {
+		t.Skip("SKIP_CONFORMANCE set")
+	}
+
+	vectors, err := testvectors.Load("testvectors/testdata")
+	if err != nil {
+		t.Fatalf("loading vectors: %s", err)
+	}
+	if len(vectors) == 0 {
+		t.Skip("no conformance vectors found; run testvectors/fetch-corpus.sh")
+	}
+
+	for _, v := range vectors {
+		v := v
+		t.Run(v.Name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", contentTypeJSON)
+				w.Write(v.Response)
+			}))
+			defer server.Close()
+
+			pool := NewEndpointPool([]Endpoint{{URL: server.URL}}, StrategyRoundRobin)
+			provider := NewPocketProvider(*http.DefaultClient, cache.Config{}, pool)
+
+			got, err := replay(provider, v)
+			if err != nil {
+				t.Fatalf("replaying %s: %s", v.Name, err)
+			}
+
+			gotJSON, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("marshaling result: %s", err)
+			}
+
+			if string(gotJSON) != string(v.Expected) {
+				t.Fatalf("%s: got %s, want %s", v.Name, gotJSON, v.Expected)
+			}
+		})
+	}
+}
+
+// replay dispatches a vector to the pocketProvider method it targets.
+func replay(provider pocketProvider, v testvectors.Vector) (interface{}, error) {
+	switch v.Method {
+	case "Node":
+		var in struct {
+			Address string `json:"address"`
+		}
+		if err := json.Unmarshal(v.Input, &in); err != nil {
+			return nil, err
+		}
+		return provider.Node(in.Address)
+
+	case "Balance":
+		var in struct {
+			Address string `json:"address"`
+		}
+		if err := json.Unmarshal(v.Input, &in); err != nil {
+			return nil, err
+		}
+		return provider.Balance(in.Address)
+
+	case "Transaction":
+		var in struct {
+			Hash string `json:"hash"`
+		}
+		if err := json.Unmarshal(v.Input, &in); err != nil {
+			return nil, err
+		}
+		return provider.Transaction(in.Hash)
+
+	case "AccountTransactions":
+		var in struct {
+			Address string `json:"address"`
+			Page    uint   `json:"page"`
+			PerPage uint   `json:"per_page"`
+			Sort    string `json:"sort"`
+		}
+		if err := json.Unmarshal(v.Input, &in); err != nil {
+			return nil, err
+		}
+		return provider.AccountTransactions(in.Address, in.Page, in.PerPage, in.Sort)
+
+	case "BlockTime":
+		var in struct {
+			Height uint `json:"height"`
+		}
+		if err := json.Unmarshal(v.Input, &in); err != nil {
+			return nil, err
+		}
+		return provider.BlockTime(in.Height)
+
+	default:
+		return nil, errUnknownVectorMethod(v.Method)
+	}
+}
+
+type errUnknownVectorMethod string
+
+func (e errUnknownVectorMethod) Error() string {
+	return "testvectors: unknown method " + string(e)
+}
@@ -0,0 +1,83 @@
+// Package errors is a typed error tree for pocket-node and provider
+// failures. It lets callers distinguish "node out of sync" from "address
+// not found" from "rate limited" via errors.As instead of string-matching a
+// wrapped fmt.Errorf.
+package errors
+
+import (
+	"fmt"
+	"time"
+)
+
+// NodeError wraps a failure from a specific pocketProvider operation. Err is
+// one of the typed errors below (or a generic error for failures that don't
+// fit a more specific type); callers use errors.As to recover it.
+type NodeError struct {
+	Op  string
+	Err error
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Op, e.Err)
+}
+
+func (e *NodeError) Unwrap() error {
+	return e.Err
+}
+
+// OutOfSyncError indicates the queried node is behind the chain tip.
+type OutOfSyncError struct {
+	Behind time.Duration
+}
+
+func (e *OutOfSyncError) Error() string {
+	return fmt.Sprintf("node is out of sync by %s", e.Behind)
+}
+
+// ExecutionError indicates the node accepted the request but failed to
+// execute it (a JSON-RPC style error response rather than an HTTP failure).
+type ExecutionError struct {
+	Err error
+}
+
+func (e *ExecutionError) Error() string {
+	return fmt.Sprintf("node execution error: %s", e.Err)
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// RPCPayloadError indicates the node's response didn't decode into the
+// shape the caller expected.
+type RPCPayloadError struct {
+	Err error
+}
+
+func (e *RPCPayloadError) Error() string {
+	return fmt.Sprintf("malformed rpc payload: %s", e.Err)
+}
+
+func (e *RPCPayloadError) Unwrap() error {
+	return e.Err
+}
+
+// NotFoundError indicates the requested address, hash, or height doesn't
+// exist on chain.
+type NotFoundError struct {
+	Resource string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", e.Resource)
+}
+
+// RateLimitedError indicates the node rejected the request for exceeding its
+// rate limit.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
+}
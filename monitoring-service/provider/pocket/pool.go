@@ -0,0 +1,326 @@
+package pocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// outOfSyncBlocks is how many blocks behind the rest of the pool's observed
+// heights a node can lag before Height reports it as out of sync.
+const outOfSyncBlocks = 3
+
+// avgBlockTime approximates pocket's block interval, used to translate a
+// height lag into the time.Duration OutOfSyncError reports.
+const avgBlockTime = 15 * time.Second
+
+// EndpointStrategy selects how EndpointPool picks a healthy endpoint for the
+// next request.
+type EndpointStrategy int
+
+const (
+	// StrategyRoundRobin cycles through healthy endpoints in order.
+	StrategyRoundRobin EndpointStrategy = iota
+	// StrategyWeighted distributes requests across healthy endpoints in
+	// proportion to their configured Weight (smooth weighted round-robin),
+	// rather than pinning every request to the single heaviest one.
+	StrategyWeighted
+	// StrategyLatencyRanked favors the endpoint with the lowest observed
+	// round-trip latency.
+	StrategyLatencyRanked
+)
+
+// maxConsecutiveFailures is how many consecutive 5xx/timeout responses mark
+// an endpoint unhealthy.
+const maxConsecutiveFailures = 3
+
+// Endpoint is one node URL in a pool, optionally weighted for
+// StrategyWeighted.
+type Endpoint struct {
+	URL    string
+	Weight int
+}
+
+type endpointState struct {
+	endpoint            Endpoint
+	consecutiveFailures int
+	unhealthy           bool
+	lastLatency         time.Duration
+	lastHeight          uint
+	currentWeight       int
+}
+
+// EndpointPool is a pluggable set of pocket-node URLs that doRequest fails
+// over across, so operators running against community RPC gateways don't
+// hard-fail when one node lags or dies.
+type EndpointPool struct {
+	mu       sync.Mutex
+	states   []*endpointState
+	strategy EndpointStrategy
+	rrNext   int
+}
+
+// NewEndpointPool builds a pool over the given endpoints. With fewer than
+// one endpoint it panics, since a pool with nothing to fail over to isn't a
+// pool.
+func NewEndpointPool(endpoints []Endpoint, strategy EndpointStrategy) *EndpointPool {
+	if len(endpoints) == 0 {
+		panic("pocket.NewEndpointPool: at least one endpoint is required")
+	}
+
+	states := make([]*endpointState, len(endpoints))
+	for i, e := range endpoints {
+		states[i] = &endpointState{endpoint: e}
+	}
+
+	return &EndpointPool{states: states, strategy: strategy}
+}
+
+// EndpointState is the point-in-time health of one pool member, as surfaced
+// by PoolStatus.
+type EndpointState struct {
+	URL                 string        `json:"url"`
+	Healthy             bool          `json:"healthy"`
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+	LastLatency         time.Duration `json:"last_latency_ns"`
+}
+
+// size returns how many endpoints are configured in the pool.
+func (p *EndpointPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.states)
+}
+
+// PoolStatus reports the health of every endpoint in the pool.
+func (p *EndpointPool) PoolStatus() []EndpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]EndpointState, len(p.states))
+	for i, s := range p.states {
+		out[i] = EndpointState{
+			URL:                 s.endpoint.URL,
+			Healthy:             !s.unhealthy,
+			ConsecutiveFailures: s.consecutiveFailures,
+			LastLatency:         s.lastLatency,
+		}
+	}
+
+	return out
+}
+
+// choose picks the next endpoint to try, preferring healthy ones. If every
+// endpoint is unhealthy, it resets all of them and tries again rather than
+// refusing to serve traffic.
+func (p *EndpointPool) choose() *endpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s := p.pickHealthy(); s != nil {
+		return s
+	}
+
+	// Every endpoint looks unhealthy; give them all a clean slate so a
+	// transient outage on all nodes doesn't permanently wedge the pool.
+	for _, s := range p.states {
+		s.unhealthy = false
+		s.consecutiveFailures = 0
+	}
+
+	return p.pickHealthy()
+}
+
+func (p *EndpointPool) pickHealthy() *endpointState {
+	switch p.strategy {
+	case StrategyWeighted:
+		return p.pickWeighted()
+
+	case StrategyLatencyRanked:
+		var best *endpointState
+		for _, s := range p.states {
+			if s.unhealthy {
+				continue
+			}
+			if best == nil || s.lastLatency < best.lastLatency {
+				best = s
+			}
+		}
+		return best
+
+	default: // StrategyRoundRobin
+		for i := 0; i < len(p.states); i++ {
+			idx := (p.rrNext + i) % len(p.states)
+			if !p.states[idx].unhealthy {
+				p.rrNext = (idx + 1) % len(p.states)
+				return p.states[idx]
+			}
+		}
+		return nil
+	}
+}
+
+// pickWeighted implements smooth weighted round-robin: every call bumps each
+// healthy endpoint's running currentWeight by its configured Weight, picks
+// whichever has accumulated the most, then discounts that one by the total
+// weight of every healthy endpoint. Over many calls this converges on each
+// endpoint being chosen in proportion to its Weight, rather than pinning
+// every request to the single heaviest endpoint like a plain max would.
+// Endpoints with Weight <= 0 are treated as weight 1 so an unweighted entry
+// still gets its fair share instead of starving.
+func (p *EndpointPool) pickWeighted() *endpointState {
+	var total int
+	var best *endpointState
+
+	for _, s := range p.states {
+		if s.unhealthy {
+			continue
+		}
+
+		weight := s.endpoint.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+
+		total += weight
+		s.currentWeight += weight
+
+		if best == nil || s.currentWeight > best.currentWeight {
+			best = s
+		}
+	}
+
+	if best != nil {
+		best.currentWeight -= total
+	}
+
+	return best
+}
+
+func (p *EndpointPool) recordResult(s *endpointState, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err != nil {
+		s.consecutiveFailures++
+		if s.consecutiveFailures >= maxConsecutiveFailures {
+			s.unhealthy = true
+		}
+		return
+	}
+
+	s.consecutiveFailures = 0
+	s.unhealthy = false
+	s.lastLatency = latency
+}
+
+// recordHeight updates the last known height for one endpoint, so
+// maxKnownHeight can tell a lagging node from the rest of the pool.
+func (p *EndpointPool) recordHeight(s *endpointState, height uint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s.lastHeight = height
+}
+
+// maxKnownHeight returns the highest height last observed from any endpoint
+// in the pool, or 0 if none has been probed yet.
+func (p *EndpointPool) maxKnownHeight() uint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var max uint
+	for _, s := range p.states {
+		if s.lastHeight > max {
+			max = s.lastHeight
+		}
+	}
+	return max
+}
+
+// behind reports how far height lags the highest height observed elsewhere
+// in the pool, as a duration. It's 0 when height is within outOfSyncBlocks
+// of the pool's best known height, or when no other endpoint has been
+// probed yet.
+func (p *EndpointPool) behind(height uint) time.Duration {
+	max := p.maxKnownHeight()
+	if max <= height || max-height <= outOfSyncBlocks {
+		return 0
+	}
+
+	return time.Duration(max-height) * avgBlockTime
+}
+
+// StartHealthProbes polls /query/height on every endpoint at the given
+// interval in the background, so a node coming back up is noticed even
+// without live request traffic. Stops when ctx is cancelled.
+func (p *EndpointPool) StartHealthProbes(ctx context.Context, client *http.Client, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.probeAll(client)
+			}
+		}
+	}()
+}
+
+func (p *EndpointPool) probeAll(client *http.Client) {
+	p.mu.Lock()
+	states := make([]*endpointState, len(p.states))
+	copy(states, p.states)
+	p.mu.Unlock()
+
+	for _, s := range states {
+		start := time.Now()
+		height, err := probeHeight(client, s.endpoint.URL)
+		p.recordResult(s, time.Since(start), err)
+		if err == nil {
+			p.recordHeight(s, height)
+		}
+	}
+}
+
+func probeHeight(client *http.Client, baseURL string) (uint, error) {
+	url := fmt.Sprintf("%s/%s", baseURL, urlPathGetHeight)
+
+	reqBody, err := json.Marshal(struct{}{})
+	if err != nil {
+		return 0, fmt.Errorf("probeHeight: %s", err)
+	}
+
+	resp, err := client.Post(url, contentTypeJSON, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return 0, fmt.Errorf("probeHeight: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return 0, fmt.Errorf("probeHeight: %s returned %d", baseURL, resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("probeHeight: %s", err)
+	}
+
+	var parsed struct {
+		Height uint `json:"height"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return 0, fmt.Errorf("probeHeight: %s", err)
+	}
+
+	return parsed.Height, nil
+}
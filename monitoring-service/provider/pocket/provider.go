@@ -3,58 +3,79 @@ package pocket
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"monitoring-service/pocket"
+	"monitoring-service/provider/pocket/cache"
+	pocketerrors "monitoring-service/provider/pocket/errors"
 )
 
 const (
 	contentTypeJSON               = "application/json; charset=UTF-8"
-	pocketEndpoint                = "https://node-000.pokt.gaagl.com/v1"
 	urlPathGetAccountTransactions = "/query/accounttxs"
 	urlPathGetTransaction         = "/query/tx"
 	urlPathGetBlock               = "/query/block"
 	urlPathGetNode                = "/query/node"
 	urlPathGetBalance             = "/query/balance"
+	urlPathGetHeight              = "/query/height"
 )
 
-type blockTimesRepo interface {
-	Get(height uint) (t time.Time, exists bool, err error)
-	Set(height uint, t time.Time) error
-}
-
 type pocketProvider struct {
-	client         *http.Client
-	blockTimesRepo blockTimesRepo
+	client            *http.Client
+	cache             *cache.Store
+	pool              *EndpointPool
+	blockTimeInFlight *singleflight.Group
 }
 
-func NewPocketProvider(c http.Client, repo blockTimesRepo) pocketProvider {
+// NewPocketProvider builds a provider that load-balances requests across
+// pool according to its configured strategy, failing over to the next
+// healthy endpoint when one returns 5xx or times out, and caches responses
+// according to cacheCfg.
+func NewPocketProvider(c http.Client, cacheCfg cache.Config, pool *EndpointPool) pocketProvider {
 	return pocketProvider{
-		client:         &c,
-		blockTimesRepo: repo,
+		client:            &c,
+		cache:             cache.NewStore(cacheCfg),
+		pool:              pool,
+		blockTimeInFlight: new(singleflight.Group),
 	}
 }
 
+// PoolStatus reports the health of every endpoint backing this provider.
+func (p pocketProvider) PoolStatus() []EndpointState {
+	return p.pool.PoolStatus()
+}
+
+// CacheStats reports the running hit/miss counters for the response cache.
+func (p pocketProvider) CacheStats() *cache.Stats {
+	return p.cache.Stats()
+}
+
 func (p pocketProvider) Node(address string) (pocket.Node, error) {
 	var fail = func(err error) (pocket.Node, error) {
-		return pocket.Node{}, fmt.Errorf("pocketProvider.Node: %s", err)
+		return pocket.Node{}, fmt.Errorf("pocketProvider.Node: %w", err)
+	}
+
+	if cached, exists, err := p.cache.GetNode(address); err == nil && exists {
+		return cached, nil
 	}
 
-	url := fmt.Sprintf("%s/%s", pocketEndpoint, urlPathGetNode)
 	nodeRequest := queryNodeRequest{Address: address}
 	var nodeResponse queryNodeResponse
 
-	body, err := p.doRequest(url, nodeRequest)
+	body, err := p.doRequest(urlPathGetNode, nodeRequest)
 	if err != nil {
 		return fail(err)
 	}
 
 	err = json.Unmarshal(body, &nodeResponse)
 	if err != nil {
-		return fail(err)
+		return fail(&pocketerrors.NodeError{Op: "pocketProvider.Node", Err: &pocketerrors.RPCPayloadError{Err: err}})
 	}
 
 	chains := make([]pocket.Chain, len(nodeResponse.Chains))
@@ -67,7 +88,7 @@ func (p pocketProvider) Node(address string) (pocket.Node, error) {
 		chains[i] = ch
 	}
 
-	return pocket.Node{
+	node := pocket.Node{
 		Address: nodeResponse.Address,
 		//Balance:           "",
 		StakedBalance: nodeResponse.StakedBalance,
@@ -76,25 +97,38 @@ func (p pocketProvider) Node(address string) (pocket.Node, error) {
 		IsSynced:      false,
 		//LatestBlockHeight: 0,
 		//LatestBlockTime:   time.Time{},
-	}, nil
+	}
+
+	if err := p.cache.SetNode(address, node); err != nil {
+		return fail(err)
+	}
+
+	return node, nil
 }
 
 func (p pocketProvider) Balance(address string) (uint, error) {
 	var fail = func(err error) (uint, error) {
-		return 0, fmt.Errorf("pocketProvider.Balance: %s", err)
+		return 0, fmt.Errorf("pocketProvider.Balance: %w", err)
+	}
+
+	if cached, exists, err := p.cache.GetBalance(address); err == nil && exists {
+		return cached, nil
 	}
 
-	url := fmt.Sprintf("%s/%s", pocketEndpoint, urlPathGetBalance)
 	balRequest := balanceRequest{Address: address}
 	var balResponse balanceResponse
 
-	body, err := p.doRequest(url, balRequest)
+	body, err := p.doRequest(urlPathGetBalance, balRequest)
 	if err != nil {
 		return fail(err)
 	}
 
 	err = json.Unmarshal(body, &balResponse)
 	if err != nil {
+		return fail(&pocketerrors.NodeError{Op: "pocketProvider.Balance", Err: &pocketerrors.RPCPayloadError{Err: err}})
+	}
+
+	if err := p.cache.SetBalance(address, balResponse.Balance); err != nil {
 		return fail(err)
 	}
 
@@ -103,30 +137,41 @@ func (p pocketProvider) Balance(address string) (uint, error) {
 
 func (p pocketProvider) BlockTime(height uint) (time.Time, error) {
 	var fail = func(err error) (time.Time, error) {
-		return time.Time{}, fmt.Errorf("pocketProvider.BlockTime: %s", err)
+		return time.Time{}, fmt.Errorf("pocketProvider.BlockTime: %w", err)
 	}
 
-	cached, exists, err := p.blockTimesRepo.Get(height)
+	cached, exists, err := p.cache.GetBlockTime(height)
 	if exists {
 		return cached, nil
 	}
 
-	url := fmt.Sprintf("%s/%s", pocketEndpoint, urlPathGetBlock)
-	blkRequest := blockRequest{Height: height}
-	var blkResponse blockResponse
-
-	body, err := p.doRequest(url, blkRequest)
+	t, err := p.fetchBlockTime(height)
 	if err != nil {
 		return fail(err)
 	}
 
-	err = json.Unmarshal(body, &blkResponse)
+	if err = p.cache.SetBlockTime(height, t); err != nil {
+		return time.Time{}, fmt.Errorf("pocketProvider.BlockTime: %w", err)
+	}
+
+	return t, nil
+}
+
+// fetchBlockTime is the uncached node round-trip behind BlockTime. It's
+// split out so BatchBlockTimes can fan these out concurrently and write the
+// results back to the cache in one batched call instead of one write per
+// height.
+func (p pocketProvider) fetchBlockTime(height uint) (time.Time, error) {
+	blkRequest := blockRequest{Height: height}
+	var blkResponse blockResponse
+
+	body, err := p.doRequest(urlPathGetBlock, blkRequest)
 	if err != nil {
-		return fail(err)
+		return time.Time{}, err
 	}
 
-	if err = p.blockTimesRepo.Set(height, blkResponse.Block.Header.Time); err != nil {
-		return time.Time{}, fmt.Errorf("pocketProvider.BlockTime: %s", err)
+	if err = json.Unmarshal(body, &blkResponse); err != nil {
+		return time.Time{}, &pocketerrors.NodeError{Op: "pocketProvider.BlockTime", Err: &pocketerrors.RPCPayloadError{Err: err}}
 	}
 
 	return blkResponse.Block.Header.Time, nil
@@ -134,21 +179,24 @@ func (p pocketProvider) BlockTime(height uint) (time.Time, error) {
 
 func (p pocketProvider) Transaction(hash string) (pocket.Transaction, error) {
 	var fail = func(err error) (pocket.Transaction, error) {
-		return pocket.Transaction{}, fmt.Errorf("pocketProvider.Transaction: %s", err)
+		return pocket.Transaction{}, fmt.Errorf("pocketProvider.Transaction: %w", err)
+	}
+
+	if cached, exists, err := p.cache.GetTransaction(hash); err == nil && exists {
+		return cached, nil
 	}
 
-	url := fmt.Sprintf("%s/%s", pocketEndpoint, urlPathGetTransaction)
 	txRequest := transactionRequest{Hash: hash}
 	var txnResponse transactionResponse
 
-	body, err := p.doRequest(url, txRequest)
+	body, err := p.doRequest(urlPathGetTransaction, txRequest)
 	if err != nil {
 		return fail(err)
 	}
 
 	err = json.Unmarshal(body, &txnResponse)
 	if err != nil {
-		return fail(err)
+		return fail(&pocketerrors.NodeError{Op: "pocketProvider.Transaction", Err: &pocketerrors.RPCPayloadError{Err: err}})
 	}
 
 	txn, err := txnResponse.Transaction()
@@ -156,15 +204,22 @@ func (p pocketProvider) Transaction(hash string) (pocket.Transaction, error) {
 		return fail(err)
 	}
 
+	if err := p.cache.SetTransaction(hash, txn); err != nil {
+		return fail(err)
+	}
+
 	return txn, nil
 }
 
 func (p pocketProvider) AccountTransactions(address string, page uint, perPage uint, sort string) ([]pocket.Transaction, error) {
 	var fail = func(err error) ([]pocket.Transaction, error) {
-		return nil, fmt.Errorf("pocketProvider.AccountTransactions: %s", err)
+		return nil, fmt.Errorf("pocketProvider.AccountTransactions: %w", err)
+	}
+
+	if cached, exists, err := p.cache.GetAccountTransactions(address, page, perPage, sort); err == nil && exists {
+		return cached, nil
 	}
 
-	url := fmt.Sprintf("%s/%s", pocketEndpoint, urlPathGetAccountTransactions)
 	txsRequest := accountTransactionsRequest{
 		Address: address,
 		Height:  0,
@@ -174,14 +229,14 @@ func (p pocketProvider) AccountTransactions(address string, page uint, perPage u
 	}
 	var txsResponse accountTransactionsResponse
 
-	body, err := p.doRequest(url, txsRequest)
+	body, err := p.doRequest(urlPathGetAccountTransactions, txsRequest)
 	if err != nil {
 		return fail(err)
 	}
 
 	err = json.Unmarshal(body, &txsResponse)
 	if err != nil {
-		return fail(err)
+		return fail(&pocketerrors.NodeError{Op: "pocketProvider.AccountTransactions", Err: &pocketerrors.RPCPayloadError{Err: err}})
 	}
 
 	var transactions []pocket.Transaction
@@ -194,30 +249,74 @@ func (p pocketProvider) AccountTransactions(address string, page uint, perPage u
 		transactions = append(transactions, txn)
 	}
 
+	if err := p.cache.SetAccountTransactions(address, page, perPage, sort, transactions); err != nil {
+		return fail(err)
+	}
+
 	return transactions, nil
 }
 
-func (p pocketProvider) doRequest(url string, reqObj interface{}) ([]byte, error) {
+// doRequest marshals reqObj and POSTs it to urlPath against a chosen
+// endpoint from the pool, failing over to the next healthy endpoint when the
+// chosen one returns a 5xx or times out.
+func (p pocketProvider) doRequest(urlPath string, reqObj interface{}) ([]byte, error) {
 	reqBody, err := json.Marshal(reqObj)
 	if err != nil {
 		return nil, fmt.Errorf("doRequest: %s", err)
 	}
 
+	var lastErr error
+	for attempt := 0; attempt < p.pool.size(); attempt++ {
+		state := p.pool.choose()
+		url := fmt.Sprintf("%s/%s", state.endpoint.URL, urlPath)
+
+		start := time.Now()
+		body, err := p.attempt(url, reqBody)
+		if err == nil {
+			p.pool.recordResult(state, time.Since(start), nil)
+			return body, nil
+		}
+
+		// A 404/429 means the endpoint answered correctly; it isn't
+		// unhealthy and there's no point failing over to another one.
+		var nodeErr *pocketerrors.NodeError
+		if errors.As(err, &nodeErr) {
+			p.pool.recordResult(state, time.Since(start), nil)
+			return nil, err
+		}
+
+		p.pool.recordResult(state, time.Since(start), err)
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("doRequest: %s", lastErr)
+}
+
+func (p pocketProvider) attempt(url string, reqBody []byte) ([]byte, error) {
 	clientReq, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return nil, fmt.Errorf("doRequest: %s", err)
+		return nil, fmt.Errorf("attempt: %s", err)
 	}
 	clientReq.Header.Set("Content-type", contentTypeJSON)
 
 	resp, err := p.client.Do(clientReq)
-	defer resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("doRequest: %s", err)
+		return nil, fmt.Errorf("attempt: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &pocketerrors.NodeError{Op: "attempt", Err: &pocketerrors.NotFoundError{Resource: url}}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, &pocketerrors.NodeError{Op: "attempt", Err: &pocketerrors.RateLimitedError{}}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, fmt.Errorf("attempt: %s returned %d", url, resp.StatusCode)
 	}
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("doRequest: %s", err)
+		return nil, fmt.Errorf("attempt: %s", err)
 	}
 
 	return body, nil
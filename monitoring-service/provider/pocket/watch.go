@@ -0,0 +1,188 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pocketerrors "monitoring-service/provider/pocket/errors"
+)
+
+// TipEvent is emitted whenever WatchTip observes the chain tip advancing.
+type TipEvent struct {
+	Height uint
+	Time   time.Time
+	// Stale mirrors MonthlyRewardsEndpoint's Stale flag: Height was behind
+	// the rest of the pool when this event fired, but still usable.
+	Stale bool
+}
+
+// NodeEvent is emitted whenever WatchNode observes a change in a node's
+// jailed state or staked balance.
+type NodeEvent struct {
+	Address       string
+	IsJailed      bool
+	StakedBalance uint
+	Height        uint
+	// Stale mirrors MonthlyRewardsEndpoint's Stale flag: Height was behind
+	// the rest of the pool when this event fired, but still usable.
+	Stale bool
+}
+
+// defaultWatchInterval is how often WatchTip/WatchNode poll the node when the
+// caller doesn't supply an interval.
+const defaultWatchInterval = 10 * time.Second
+
+// WatchTip long-polls /query/height and pushes a TipEvent on the returned
+// channel each time the tip advances. The channel is closed when ctx is
+// cancelled.
+func (p pocketProvider) WatchTip(ctx context.Context, interval time.Duration) (<-chan TipEvent, error) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	events := make(chan TipEvent)
+
+	go func() {
+		defer close(events)
+
+		var lastHeight uint
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				height, err := p.Height()
+				stale, ok := staleOrFatal(err)
+				if !ok {
+					continue
+				}
+
+				if height <= lastHeight {
+					continue
+				}
+
+				lastHeight = height
+				select {
+				case events <- TipEvent{Height: height, Time: time.Now(), Stale: stale}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// staleOrFatal classifies a Height error: a nil error or an OutOfSyncError
+// still carries a usable height (ok=true, stale reflecting which case it
+// was), while anything else is a real failure the caller should skip this
+// tick for (ok=false).
+func staleOrFatal(err error) (stale bool, ok bool) {
+	if err == nil {
+		return false, true
+	}
+
+	var outOfSync *pocketerrors.OutOfSyncError
+	if errors.As(err, &outOfSync) {
+		return true, true
+	}
+
+	return false, false
+}
+
+// WatchNode long-polls the node's jailed state and staked balance, pushing a
+// NodeEvent whenever either changes.
+func (p pocketProvider) WatchNode(ctx context.Context, address string, interval time.Duration) (<-chan NodeEvent, error) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	events := make(chan NodeEvent)
+
+	go func() {
+		defer close(events)
+
+		var lastKnown *NodeEvent
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				node, err := p.Node(address)
+				if err != nil {
+					continue
+				}
+
+				height, err := p.Height()
+				stale, ok := staleOrFatal(err)
+				if !ok {
+					continue
+				}
+
+				current := NodeEvent{
+					Address:       address,
+					IsJailed:      node.IsJailed,
+					StakedBalance: node.StakedBalance,
+					Height:        height,
+					Stale:         stale,
+				}
+
+				if lastKnown != nil && lastKnown.IsJailed == current.IsJailed && lastKnown.StakedBalance == current.StakedBalance {
+					continue
+				}
+
+				lastKnown = &current
+				select {
+				case events <- current:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// Height returns the chain tip as reported by the chosen endpoint. If that
+// endpoint is lagging the rest of the pool by more than outOfSyncBlocks, the
+// (still usable) height is returned alongside an OutOfSyncError so callers
+// can distinguish a degraded response from a fully caught-up one.
+func (p pocketProvider) Height() (uint, error) {
+	var fail = func(err error) (uint, error) {
+		return 0, fmt.Errorf("pocketProvider.Height: %w", err)
+	}
+
+	body, err := p.doRequest(urlPathGetHeight, struct{}{})
+	if err != nil {
+		return fail(err)
+	}
+
+	var heightResponse struct {
+		Height uint `json:"height"`
+	}
+
+	if err = json.Unmarshal(body, &heightResponse); err != nil {
+		return fail(err)
+	}
+
+	if behind := p.pool.behind(heightResponse.Height); behind > 0 {
+		_, err := fail(&pocketerrors.NodeError{
+			Op:  "pocketProvider.Height",
+			Err: &pocketerrors.OutOfSyncError{Behind: behind},
+		})
+		return heightResponse.Height, err
+	}
+
+	return heightResponse.Height, nil
+}